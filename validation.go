@@ -0,0 +1,415 @@
+// Package envconfig provides configuration loading and validation helpers.
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ValidationError describes a single validation failure for a specific field.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("validation error for field '%s': %s", e.Field, e.Message)
+}
+
+// ValidationErrors collects every ValidationError produced by a single
+// ValidateStruct call.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 0 {
+		return "no validation errors"
+	}
+
+	parts := make([]string, len(e))
+	for i, err := range e {
+		parts[i] = err.Error()
+	}
+
+	return fmt.Sprintf("validation failed with %d error(s): %s", len(e), strings.Join(parts, "; "))
+}
+
+// ValidationFunc implements a single named validation rule. value is the
+// field being checked and param is whatever follows "=" for that rule in
+// the struct tag (empty when the rule takes no parameter).
+type ValidationFunc func(value reflect.Value, param string) error
+
+// Validator validates a configuration struct against its field tags.
+type Validator interface {
+	ValidateStruct(config any) error
+	RegisterValidation(tag string, fn ValidationFunc)
+}
+
+// StructValidationFunc implements a whole-struct rule that cannot be
+// expressed per-field, such as "exactly one of A/B must be set". s is the
+// struct value being validated, never a pointer to it: the top-level config
+// (dereferenced when ValidateStruct was given a pointer), or a nested/dived
+// struct.
+type StructValidationFunc func(s any) []ValidationError
+
+// StructValidator is the default Validator implementation. It validates
+// exported struct fields using a registry of named rules driven by struct
+// tags, recursing into nested structs and pointers to structs. Struct tags
+// are parsed once per reflect.Type into a cached validationPlan rather than
+// on every ValidateStruct call.
+type StructValidator struct {
+	mu                sync.RWMutex
+	rules             map[string]ValidationFunc
+	aliases           map[string]string
+	structValidations map[reflect.Type][]StructValidationFunc
+	plans             sync.Map // reflect.Type -> *validationPlan
+}
+
+// NewValidator returns a StructValidator with the built-in rule set
+// registered.
+func NewValidator() *StructValidator {
+	v := &StructValidator{
+		rules:             make(map[string]ValidationFunc),
+		aliases:           make(map[string]string),
+		structValidations: make(map[reflect.Type][]StructValidationFunc),
+	}
+	v.registerBuiltins()
+	return v
+}
+
+// RegisterValidation registers fn under tag, making it available as an
+// entry in the combined `validate:"tag=param"` tag. It is not looked up as
+// a standalone struct tag (`tag:"param"`) - only the four legacy names
+// required/min/max/pattern are ever read that way, and which tag names are
+// read is hard-coded in buildPlan. Registering an existing tag replaces it,
+// which lets callers override a built-in rule: the replacement runs for the
+// combined form and, for required/min/max/pattern, for the legacy
+// standalone tags too.
+func (v *StructValidator) RegisterValidation(tag string, fn ValidationFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.rules[tag] = fn
+}
+
+// RegisterAlias defines alias as shorthand for the comma-separated tags
+// string, so that `validate:"alias"` expands to `validate:"tags"` wherever
+// it appears. Aliases may reference other aliases; a cycle is broken by
+// leaving the inner occurrence unexpanded. Like RegisterValidation and
+// RegisterStructValidation, expansion happens live at validation time, so
+// a type validated before its aliases were registered still picks them up
+// on every later call - there is no need to register aliases before the
+// first ValidateStruct call. The one exception: an alias that expands to
+// include a `dive` marker is not recognized as one, because dive/non-dive
+// splitting happens on the tag as written, before alias expansion; put
+// dive directly in the field's tag instead.
+func (v *StructValidator) RegisterAlias(alias, tags string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.aliases[alias] = tags
+}
+
+// RegisterStructValidation registers fn to run, in addition to per-field
+// validation, whenever ValidateStruct encounters a value of any of the
+// given types. types are sample values (typically zero values) used only
+// to capture their reflect.Type.
+func (v *StructValidator) RegisterStructValidation(fn StructValidationFunc, types ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		v.structValidations[typ] = append(v.structValidations[typ], fn)
+	}
+}
+
+func (v *StructValidator) rule(tag string) (ValidationFunc, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	fn, ok := v.rules[tag]
+	return fn, ok
+}
+
+func (v *StructValidator) alias(name string) (string, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	tags, ok := v.aliases[name]
+	return tags, ok
+}
+
+func (v *StructValidator) structValidatorsFor(typ reflect.Type) []StructValidationFunc {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.structValidations[typ]
+}
+
+// ValidateStruct validates config, which must be a struct or a non-nil
+// pointer to one. It returns a ValidationErrors when one or more fields
+// fail validation, or nil when config is valid.
+func (v *StructValidator) ValidateStruct(config any) error {
+	if config == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+
+	val := reflect.ValueOf(config)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return fmt.Errorf("configuration pointer cannot be nil")
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("configuration must be a struct")
+	}
+
+	var errs ValidationErrors
+	ctx := &validationContext{root: val}
+	v.validateStruct(val, v.planFor(val.Type()), "", ctx, &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validationContext carries the state that is constant for a single
+// ValidateStruct call but needed at any depth of the recursive walk: the
+// root struct value, so that cross-struct tags like `eqcsfield=Parent.Field`
+// can be resolved regardless of how deep the current field is nested.
+type validationContext struct {
+	root reflect.Value
+}
+
+// validateStruct walks val's fields using its precomputed plan, applying
+// tag-based rules and recursing into nested structs (directly, through a
+// pointer, or via dive) using each field's linked plan rather than looking
+// one up or re-parsing tags.
+func (v *StructValidator) validateStruct(val reflect.Value, plan *validationPlan, prefix string, ctx *validationContext, errs *ValidationErrors) {
+	for i := range plan.fields {
+		fp := &plan.fields[i]
+		fieldVal := val.Field(fp.index)
+		name := fp.localName
+		if prefix != "" {
+			name = prefix + "." + fp.localName
+		}
+
+		switch {
+		case fp.container:
+			v.validateContainerRules(fieldVal, fp, name, val, ctx, errs)
+			v.dive(fieldVal, fp, name, ctx, errs)
+		case fp.isStruct:
+			v.validateField(fieldVal, fp, name, val, ctx, errs)
+			v.validateStruct(fieldVal, fp.nestedPlan, name, ctx, errs)
+		case fp.ptrToStruct:
+			if !fieldVal.IsNil() {
+				v.validateStruct(fieldVal.Elem(), fp.nestedPlan, name, ctx, errs)
+			}
+		default:
+			v.validateField(fieldVal, fp, name, val, ctx, errs)
+		}
+	}
+
+	v.runStructValidations(val, errs)
+}
+
+// runStructValidations invokes every StructValidationFunc registered for
+// val's type, after per-field validation has already run for val.
+func (v *StructValidator) runStructValidations(val reflect.Value, errs *ValidationErrors) {
+	for _, fn := range v.structValidatorsFor(val.Type()) {
+		*errs = append(*errs, fn(val.Interface())...)
+	}
+}
+
+// validateField runs every rule in fp against fieldVal: the legacy
+// one-tag-per-rule rules (`required:"true"`, `min:"3"`, ...), the
+// conditional required_if/required_unless rules, and the combined
+// `validate:"required,min=3"` rules. parent is the struct that directly
+// contains the field, used to resolve same-struct field references for
+// cross-field rules.
+func (v *StructValidator) validateField(fieldVal reflect.Value, fp *fieldPlan, name string, parent reflect.Value, ctx *validationContext, errs *ValidationErrors) {
+	for _, rule := range fp.legacyRules {
+		v.applyRule(rule.tag, rule.param, fieldVal, name, errs)
+	}
+
+	for _, rule := range fp.conditionalRules {
+		v.applyConditionalRequired(rule.tag, rule.param, fieldVal, name, parent, ctx, errs)
+	}
+
+	v.applyValidateRules(fp.validateRules, fieldVal, name, parent, ctx, errs)
+}
+
+// applyValidateRules dispatches each rule parsed from a combined
+// `validate:"..."` tag to the right handler: cross-field comparisons,
+// conditional required, or a plain registered ValidationFunc. Shared by
+// validateField (scalar/struct fields) and validateContainerRules
+// (container fields), which differ only in which legacy rules they run
+// alongside this. rules is expanded here, at apply time, rather than when
+// the plan was built, so a RegisterAlias call takes effect for every
+// field that references it no matter when it runs relative to earlier
+// ValidateStruct calls.
+func (v *StructValidator) applyValidateRules(rules []tagRule, fieldVal reflect.Value, name string, parent reflect.Value, ctx *validationContext, errs *ValidationErrors) {
+	for _, rule := range v.expandRules(rules, map[string]bool{}) {
+		switch {
+		case isCrossFieldTag(rule.tag):
+			v.applyCrossFieldRule(rule.tag, rule.param, fieldVal, name, parent, ctx, errs)
+		case rule.tag == "required_if" || rule.tag == "required_unless":
+			v.applyConditionalRequired(rule.tag, rule.param, fieldVal, name, parent, ctx, errs)
+		default:
+			v.applyRule(rule.tag, rule.param, fieldVal, name, errs)
+		}
+	}
+}
+
+func (v *StructValidator) applyRule(tag, param string, fieldVal reflect.Value, name string, errs *ValidationErrors) {
+	fn, ok := v.rule(tag)
+	if !ok {
+		return
+	}
+	if err := fn(fieldVal, param); err != nil {
+		*errs = append(*errs, ValidationError{Field: name, Message: err.Error()})
+	}
+}
+
+type tagRule struct {
+	tag      string
+	param    string
+	hasParam bool // distinguishes "min" with an empty param from a bare name eligible for alias expansion
+}
+
+// parseValidateTag splits a comma-separated `validate:"..."` tag into
+// individual rules, each optionally carrying a "=param" suffix. It does
+// not expand aliases: that happens in expandRules, at apply time rather
+// than here, so a RegisterAlias call made after this tag was last parsed
+// still takes effect (see RegisterAlias).
+func parseValidateTag(tag string) []tagRule {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, ",")
+	rules := make([]tagRule, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, param, hasParam := strings.Cut(part, "=")
+		rules = append(rules, tagRule{tag: name, param: param, hasParam: hasParam})
+	}
+	return rules
+}
+
+// expandRules resolves any registered aliases in rules, in the order they
+// were parsed. Only a bare rule name with no "=param" is eligible for
+// expansion, matching the historical `validate:"alias"` form. seen tracks
+// alias names already expanded in this call so a cyclic alias definition
+// terminates - by leaving the inner occurrence unexpanded - instead of
+// recursing forever.
+func (v *StructValidator) expandRules(rules []tagRule, seen map[string]bool) []tagRule {
+	out := make([]tagRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.hasParam {
+			if expansion, ok := v.alias(rule.tag); ok && !seen[rule.tag] {
+				seen[rule.tag] = true
+				out = append(out, v.expandRules(parseValidateTag(expansion), seen)...)
+				continue
+			}
+		}
+		out = append(out, rule)
+	}
+	return out
+}
+
+// getFieldName returns the dotted, prefix-qualified name used to report
+// errors for field. It honours a `mapstructure` tag and otherwise falls
+// back to the lowercased Go field name.
+func (v *StructValidator) getFieldName(field reflect.StructField, prefix string) string {
+	name := field.Tag.Get("mapstructure")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// isRequired reports whether field carries a `required:"true"` tag.
+func (v *StructValidator) isRequired(field reflect.StructField) bool {
+	return field.Tag.Get("required") == "true"
+}
+
+// isEmpty reports whether value is the "unset" value for its kind: nil for
+// pointers, zero-length for strings/slices/arrays/maps, and never for
+// anything else.
+func (v *StructValidator) isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// parseInt parses the leading optionally-signed run of digits in s,
+// returning defaultVal when s has no such prefix.
+func (v *StructValidator) parseInt(s string, defaultVal int) int {
+	if s == "" {
+		return defaultVal
+	}
+
+	i := 0
+	neg := false
+	if s[0] == '+' || s[0] == '-' {
+		neg = s[0] == '-'
+		i = 1
+	}
+
+	start := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == start {
+		return defaultVal
+	}
+
+	n, err := strconv.Atoi(s[start:i])
+	if err != nil {
+		return defaultVal
+	}
+	if neg {
+		n = -n
+	}
+	return n
+}
+
+// matchesPattern reports whether input satisfies the named pattern.
+// Unknown pattern names are treated as satisfied, matching the permissive
+// behaviour of the rest of the built-in rule set.
+func (v *StructValidator) matchesPattern(input, pattern string) bool {
+	switch pattern {
+	case "alphanumeric":
+		return v.isAlphanumeric(input)
+	default:
+		return true
+	}
+}
+
+// isAlphanumeric reports whether input consists solely of ASCII letters
+// and digits. An empty string is considered alphanumeric.
+func (v *StructValidator) isAlphanumeric(input string) bool {
+	for _, r := range input {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit {
+			return false
+		}
+	}
+	return true
+}
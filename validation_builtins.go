@@ -0,0 +1,223 @@
+package envconfig
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	emailPattern    = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// registerBuiltins installs the default rule set: the legacy
+// required/min/max/pattern quartet plus the go-playground/validator-style
+// vocabulary usable from a combined `validate:"..."` tag.
+func (v *StructValidator) registerBuiltins() {
+	v.rules["required"] = v.validateRequired
+	v.rules["min"] = v.validateMin
+	v.rules["max"] = v.validateMax
+	v.rules["pattern"] = v.validatePattern
+	v.rules["len"] = v.validateLen
+	v.rules["gt"] = v.validateGt
+	v.rules["gte"] = v.validateGte
+	v.rules["lt"] = v.validateLt
+	v.rules["lte"] = v.validateLte
+	v.rules["oneof"] = v.validateOneof
+	v.rules["email"] = v.validateEmail
+	v.rules["url"] = v.validateURL
+	v.rules["uuid"] = v.validateUUID
+	v.rules["ip"] = v.validateIP
+	v.rules["hostname"] = v.validateHostname
+}
+
+func (v *StructValidator) validateRequired(value reflect.Value, _ string) error {
+	if v.isEmpty(value) {
+		return fmt.Errorf("field is required")
+	}
+	return nil
+}
+
+// numericValue returns value as a float64 and true when value is a kind
+// that min/max/gt/gte/lt/lte compare numerically rather than by length.
+func numericValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// lengthOf returns the length of value for kinds min/max/len compare by
+// length, and true when value is such a kind.
+func lengthOf(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), true
+	default:
+		return 0, false
+	}
+}
+
+func (v *StructValidator) validateMin(value reflect.Value, param string) error {
+	if num, ok := numericValue(value); ok {
+		threshold, _ := strconv.ParseFloat(param, 64)
+		if num < threshold {
+			return fmt.Errorf("minimum value is %s", param)
+		}
+		return nil
+	}
+
+	n := v.parseInt(param, 0)
+	if length, ok := lengthOf(value); ok {
+		if length < n {
+			return fmt.Errorf("minimum length is %d", n)
+		}
+	}
+	return nil
+}
+
+func (v *StructValidator) validateMax(value reflect.Value, param string) error {
+	if num, ok := numericValue(value); ok {
+		threshold, _ := strconv.ParseFloat(param, 64)
+		if num > threshold {
+			return fmt.Errorf("maximum value is %s", param)
+		}
+		return nil
+	}
+
+	n := v.parseInt(param, 0)
+	if length, ok := lengthOf(value); ok {
+		if length > n {
+			return fmt.Errorf("maximum length is %d", n)
+		}
+	}
+	return nil
+}
+
+func (v *StructValidator) validateLen(value reflect.Value, param string) error {
+	n := v.parseInt(param, 0)
+	if length, ok := lengthOf(value); ok && length != n {
+		return fmt.Errorf("length must be exactly %d", n)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateGt(value reflect.Value, param string) error {
+	n, _ := strconv.ParseFloat(param, 64)
+	if num, ok := numericValue(value); ok && !(num > n) {
+		return fmt.Errorf("must be greater than %s", param)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateGte(value reflect.Value, param string) error {
+	n, _ := strconv.ParseFloat(param, 64)
+	if num, ok := numericValue(value); ok && !(num >= n) {
+		return fmt.Errorf("must be greater than or equal to %s", param)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateLt(value reflect.Value, param string) error {
+	n, _ := strconv.ParseFloat(param, 64)
+	if num, ok := numericValue(value); ok && !(num < n) {
+		return fmt.Errorf("must be less than %s", param)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateLte(value reflect.Value, param string) error {
+	n, _ := strconv.ParseFloat(param, 64)
+	if num, ok := numericValue(value); ok && !(num <= n) {
+		return fmt.Errorf("must be less than or equal to %s", param)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateOneof(value reflect.Value, param string) error {
+	if value.Kind() != reflect.String {
+		return nil
+	}
+
+	options := strings.Fields(param)
+	current := value.String()
+	for _, opt := range options {
+		if opt == current {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of [%s]", strings.Join(options, " "))
+}
+
+func (v *StructValidator) validatePattern(value reflect.Value, param string) error {
+	if value.Kind() != reflect.String {
+		return nil
+	}
+	if !v.matchesPattern(value.String(), param) {
+		return fmt.Errorf("does not match required pattern: %s", param)
+	}
+	return nil
+}
+
+func (v *StructValidator) validateEmail(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || v.isEmpty(value) {
+		return nil
+	}
+	if !emailPattern.MatchString(value.String()) {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
+
+func (v *StructValidator) validateURL(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || v.isEmpty(value) {
+		return nil
+	}
+	u, err := url.Parse(value.String())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("must be a valid URL")
+	}
+	return nil
+}
+
+func (v *StructValidator) validateUUID(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || v.isEmpty(value) {
+		return nil
+	}
+	if !uuidPattern.MatchString(value.String()) {
+		return fmt.Errorf("must be a valid UUID")
+	}
+	return nil
+}
+
+func (v *StructValidator) validateIP(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || v.isEmpty(value) {
+		return nil
+	}
+	if net.ParseIP(value.String()) == nil {
+		return fmt.Errorf("must be a valid IP address")
+	}
+	return nil
+}
+
+func (v *StructValidator) validateHostname(value reflect.Value, _ string) error {
+	if value.Kind() != reflect.String || v.isEmpty(value) {
+		return nil
+	}
+	if !hostnamePattern.MatchString(value.String()) {
+		return fmt.Errorf("must be a valid hostname")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_RegisterValidation(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterValidation("even", func(value reflect.Value, _ string) error {
+		if value.Kind() == reflect.Int && value.Int()%2 != 0 {
+			return fmt.Errorf("must be even")
+		}
+		return nil
+	})
+
+	type TestStruct struct {
+		Count int `validate:"even"`
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Count: 4}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Count: 3})
+	if err == nil || !strings.Contains(err.Error(), "must be even") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'must be even'", err)
+	}
+}
+
+func TestStructValidator_RegisterValidation_OverridesBuiltin(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterValidation("required", func(value reflect.Value, _ string) error {
+		return fmt.Errorf("custom required rule")
+	})
+
+	type TestStruct struct {
+		Name string `required:"true"`
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Name: "set"})
+	if err == nil || !strings.Contains(err.Error(), "custom required rule") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'custom required rule'", err)
+	}
+}
+
+func TestStructValidator_ValidateTag(t *testing.T) {
+	type TestStruct struct {
+		Name  string `validate:"required,min=2,max=5"`
+		Grade string `validate:"oneof=a b c"`
+		Email string `validate:"email"`
+	}
+
+	tests := []struct {
+		name      string
+		config    TestStruct
+		wantError bool
+		errorMsg  string
+	}{
+		{
+			name:   "valid",
+			config: TestStruct{Name: "abc", Grade: "b", Email: "user@example.com"},
+		},
+		{
+			name:      "name too short",
+			config:    TestStruct{Name: "a", Grade: "b", Email: "user@example.com"},
+			wantError: true,
+			errorMsg:  "minimum length",
+		},
+		{
+			name:      "grade not in oneof",
+			config:    TestStruct{Name: "abc", Grade: "z", Email: "user@example.com"},
+			wantError: true,
+			errorMsg:  "must be one of",
+		},
+		{
+			name:      "invalid email",
+			config:    TestStruct{Name: "abc", Grade: "b", Email: "not-an-email"},
+			wantError: true,
+			errorMsg:  "valid email",
+		},
+	}
+
+	validator := NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateStruct(&tt.config)
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("ValidateStruct() expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("ValidateStruct() error = %v, want to contain %v", err.Error(), tt.errorMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateStruct() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStructValidator_NumericMinMax(t *testing.T) {
+	type TestStruct struct {
+		Age int `validate:"min=18,max=65"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Age: 30}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Age: 10})
+	if err == nil || !strings.Contains(err.Error(), "minimum value") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'minimum value'", err)
+	}
+
+	err = validator.ValidateStruct(&TestStruct{Age: 99})
+	if err == nil || !strings.Contains(err.Error(), "maximum value") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'maximum value'", err)
+	}
+}
+
+func TestStructValidator_FractionalMinMax(t *testing.T) {
+	type TestStruct struct {
+		Ratio float64 `validate:"min=0.5,max=2.5"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Ratio: 1.5}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Ratio: 0.4})
+	if err == nil || !strings.Contains(err.Error(), "minimum value is 0.5") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'minimum value is 0.5'", err)
+	}
+
+	err = validator.ValidateStruct(&TestStruct{Ratio: 2.6})
+	if err == nil || !strings.Contains(err.Error(), "maximum value is 2.5") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'maximum value is 2.5'", err)
+	}
+}
@@ -0,0 +1,50 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// applyConditionalRequired implements `required_if=OtherField value` and
+// `required_unless=OtherField value`: fieldVal is only required to be set
+// when the referenced sibling field's value does (or does not) match the
+// given value. It checks IsZero rather than the general isEmpty rule so
+// that numeric and boolean fields, which isEmpty never treats as unset,
+// can still be made conditionally required.
+func (v *StructValidator) applyConditionalRequired(tag, param string, fieldVal reflect.Value, name string, parent reflect.Value, ctx *validationContext, errs *ValidationErrors) {
+	refName, wantValue, ok := strings.Cut(strings.TrimSpace(param), " ")
+	if !ok {
+		return
+	}
+	wantValue = strings.TrimSpace(wantValue)
+
+	other, ok := resolveFieldReference(ctx, parent, refName)
+	if !ok {
+		return
+	}
+
+	matches := fmt.Sprint(other.Interface()) == wantValue
+
+	var triggered bool
+	switch tag {
+	case "required_if":
+		triggered = matches
+	case "required_unless":
+		triggered = !matches
+	}
+
+	if triggered && fieldVal.IsZero() {
+		*errs = append(*errs, ValidationError{
+			Field:   name,
+			Message: fmt.Sprintf("field is required when '%s' %s '%s'", refName, conditionDescription(tag), wantValue),
+		})
+	}
+}
+
+func conditionDescription(tag string) string {
+	if tag == "required_unless" {
+		return "is not"
+	}
+	return "is"
+}
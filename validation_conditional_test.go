@@ -0,0 +1,46 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_RequiredIf(t *testing.T) {
+	type TestStruct struct {
+		Mode       string
+		MaxRetries int `validate:"required_if=Mode custom"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Mode: "default", MaxRetries: 0}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Mode: "custom", MaxRetries: 3}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Mode: "custom", MaxRetries: 0})
+	if err == nil || !strings.Contains(err.Error(), "maxretries") {
+		t.Errorf("ValidateStruct() error = %v, want required_if violation on maxretries", err)
+	}
+}
+
+func TestStructValidator_RequiredUnless(t *testing.T) {
+	type TestStruct struct {
+		Plan      string
+		SeatCount int `validate:"required_unless=Plan trial"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Plan: "trial", SeatCount: 0}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Plan: "enterprise", SeatCount: 0})
+	if err == nil || !strings.Contains(err.Error(), "seatcount") {
+		t.Errorf("ValidateStruct() error = %v, want required_unless violation on seatcount", err)
+	}
+}
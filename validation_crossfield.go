@@ -0,0 +1,163 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// crossFieldTags are the rule names that compare a field against another
+// field instead of against a fixed parameter, and therefore need the
+// validationContext rather than a plain ValidationFunc.
+var crossFieldTags = map[string]bool{
+	"eqfield":   true,
+	"nefield":   true,
+	"gtfield":   true,
+	"gtefield":  true,
+	"ltfield":   true,
+	"ltefield":  true,
+	"eqcsfield": true,
+	"necsfield": true,
+}
+
+func isCrossFieldTag(tag string) bool {
+	return crossFieldTags[tag]
+}
+
+// applyCrossFieldRule resolves the field referenced by param (a sibling
+// name such as "Password", or, when param contains a dot, a root-relative
+// path such as "Parent.Field"; the choice follows the dot, not the tag, so
+// eqcsfield/necsfield behave exactly like eqfield/nefield) and compares it
+// against fieldVal, recording a ValidationError that names both fields on
+// mismatch.
+func (v *StructValidator) applyCrossFieldRule(tag, param string, fieldVal reflect.Value, name string, parent reflect.Value, ctx *validationContext, errs *ValidationErrors) {
+	other, ok := resolveFieldReference(ctx, parent, param)
+	if !ok {
+		return
+	}
+
+	switch tag {
+	case "eqfield", "eqcsfield":
+		if !reflect.DeepEqual(fieldVal.Interface(), other.Interface()) {
+			*errs = append(*errs, ValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("field '%s' must equal field '%s'", name, param),
+			})
+		}
+	case "nefield", "necsfield":
+		if reflect.DeepEqual(fieldVal.Interface(), other.Interface()) {
+			*errs = append(*errs, ValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("field '%s' must not equal field '%s'", name, param),
+			})
+		}
+	case "gtfield", "gtefield", "ltfield", "ltefield":
+		cmp, ok := compareOrdered(fieldVal, other)
+		if !ok {
+			return
+		}
+		if violatesOrder(tag, cmp) {
+			*errs = append(*errs, ValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("field '%s' %s field '%s'", name, orderDescription(tag), param),
+			})
+		}
+	}
+}
+
+func violatesOrder(tag string, cmp int) bool {
+	switch tag {
+	case "gtfield":
+		return cmp <= 0
+	case "gtefield":
+		return cmp < 0
+	case "ltfield":
+		return cmp >= 0
+	case "ltefield":
+		return cmp > 0
+	default:
+		return false
+	}
+}
+
+func orderDescription(tag string) string {
+	switch tag {
+	case "gtfield":
+		return "must be greater than"
+	case "gtefield":
+		return "must be greater than or equal to"
+	case "ltfield":
+		return "must be less than"
+	default:
+		return "must be less than or equal to"
+	}
+}
+
+// resolveFieldReference resolves ref against parent (a same-struct sibling
+// reference like "Password") or, when ref contains a dot, against
+// ctx.root (a cross-struct reference like "Parent.Field").
+func resolveFieldReference(ctx *validationContext, parent reflect.Value, ref string) (reflect.Value, bool) {
+	if !strings.Contains(ref, ".") {
+		if !parent.IsValid() || parent.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field := parent.FieldByName(ref)
+		return field, field.IsValid() && field.CanInterface()
+	}
+
+	cur := ctx.root
+	for _, segment := range strings.Split(ref, ".") {
+		if cur.Kind() == reflect.Ptr {
+			if cur.IsNil() {
+				return reflect.Value{}, false
+			}
+			cur = cur.Elem()
+		}
+		if cur.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		cur = cur.FieldByName(segment)
+		if !cur.IsValid() || !cur.CanInterface() {
+			return reflect.Value{}, false
+		}
+	}
+	return cur, true
+}
+
+// compareOrdered returns -1, 0 or 1 when a and b can be ordered (numeric
+// kinds, strings, or time.Time), and ok=false when they cannot.
+func compareOrdered(a, b reflect.Value) (cmp int, ok bool) {
+	if an, aok := numericValue(a); aok {
+		if bn, bok := numericValue(b); bok {
+			switch {
+			case an < bn:
+				return -1, true
+			case an > bn:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+
+	if at, aok := a.Interface().(time.Time); aok {
+		if bt, bok := b.Interface().(time.Time); bok {
+			switch {
+			case at.Before(bt):
+				return -1, true
+			case at.After(bt):
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	return 0, false
+}
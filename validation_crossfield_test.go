@@ -0,0 +1,96 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStructValidator_CrossFieldRules(t *testing.T) {
+	type Credentials struct {
+		Password        string `validate:"required"`
+		ConfirmPassword string `validate:"eqfield=Password"`
+		OldPassword     string `validate:"nefield=Password"`
+	}
+
+	validator := NewValidator()
+
+	valid := Credentials{Password: "s3cret", ConfirmPassword: "s3cret", OldPassword: "different"}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	mismatch := Credentials{Password: "s3cret", ConfirmPassword: "nope", OldPassword: "different"}
+	err := validator.ValidateStruct(&mismatch)
+	if err == nil || !strings.Contains(err.Error(), "must equal field 'Password'") {
+		t.Errorf("ValidateStruct() error = %v, want to contain eqfield violation", err)
+	}
+
+	reused := Credentials{Password: "s3cret", ConfirmPassword: "s3cret", OldPassword: "s3cret"}
+	err = validator.ValidateStruct(&reused)
+	if err == nil || !strings.Contains(err.Error(), "must not equal field 'Password'") {
+		t.Errorf("ValidateStruct() error = %v, want to contain nefield violation", err)
+	}
+}
+
+func TestStructValidator_GtFieldWithTime(t *testing.T) {
+	type Window struct {
+		StartDate time.Time
+		EndDate   time.Time `validate:"gtfield=StartDate"`
+	}
+
+	validator := NewValidator()
+	now := time.Now()
+
+	valid := Window{StartDate: now, EndDate: now.Add(time.Hour)}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := Window{StartDate: now, EndDate: now.Add(-time.Hour)}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil || !strings.Contains(err.Error(), "must be greater than field 'StartDate'") {
+		t.Errorf("ValidateStruct() error = %v, want to contain gtfield violation", err)
+	}
+}
+
+func TestStructValidator_CrossFieldUnexportedSiblingIsUnresolved(t *testing.T) {
+	type Credentials struct {
+		Password string `validate:"eqfield=secret"`
+		secret   string
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&Credentials{Password: "s3cret", secret: "s3cret"}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+}
+
+func TestStructValidator_CrossStructField(t *testing.T) {
+	type Parent struct {
+		Region string
+	}
+
+	type Child struct {
+		Region string `validate:"eqcsfield=Parent.Region"`
+	}
+
+	type TestStruct struct {
+		Parent Parent
+		Child  Child
+	}
+
+	validator := NewValidator()
+
+	valid := TestStruct{Parent: Parent{Region: "eu"}, Child: Child{Region: "eu"}}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := TestStruct{Parent: Parent{Region: "eu"}, Child: Child{Region: "us"}}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil || !strings.Contains(err.Error(), "must equal field 'Parent.Region'") {
+		t.Errorf("ValidateStruct() error = %v, want to contain eqcsfield violation", err)
+	}
+}
@@ -0,0 +1,106 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// splitDiveRules splits the rules parsed from a combined `validate:"..."`
+// tag around a `dive` marker, go-playground/validator style: whatever
+// comes before dive describes the container itself (its length, its
+// emptiness, ...) and whatever comes after describes each element. A tag
+// with no dive marker is all container rules; there is no way to reach
+// elemRules without one. This runs on the raw, not-yet-alias-expanded
+// rules (see fieldPlan.validateRules), so the dive marker must appear
+// literally in the field's own tag - an alias that itself expands to
+// include "dive" is not recognized as a split point. Nil pointer elements
+// are skipped, so no element rule runs on them.
+func splitDiveRules(rules []tagRule) (container, elem []tagRule) {
+	for i, rule := range rules {
+		if rule.tag == "dive" {
+			return rules[:i], rules[i+1:]
+		}
+	}
+	return rules, nil
+}
+
+// validateContainerRules applies the rules that describe a slice/array/map
+// field itself rather than its elements: the legacy `required:"true"` tag,
+// required_if/required_unless, and whatever a combined `validate:"..."`
+// tag names before an explicit `dive` marker (all of it, when there is no
+// dive marker at all). Legacy min/max/pattern only run here when the
+// elements are (pointers to) structs - see fieldPlan.legacyElemRules -
+// since a struct element is always recursed into rather than measured, so
+// min/max/pattern on that kind of container can only mean the container's
+// own length. For scalar elements those tags dive instead.
+func (v *StructValidator) validateContainerRules(fieldVal reflect.Value, fp *fieldPlan, name string, parent reflect.Value, ctx *validationContext, errs *ValidationErrors) {
+	for _, rule := range fp.legacyRules {
+		if rule.tag == "required" || fp.elemPlan != nil {
+			v.applyRule(rule.tag, rule.param, fieldVal, name, errs)
+		}
+	}
+
+	for _, rule := range fp.conditionalRules {
+		v.applyConditionalRequired(rule.tag, rule.param, fieldVal, name, parent, ctx, errs)
+	}
+
+	v.applyValidateRules(fp.validateRules, fieldVal, name, parent, ctx, errs)
+}
+
+// shouldDive reports whether fp's elements need a walk at all: either they
+// are (pointers to) structs that always recurse, a legacy min/max/pattern
+// tag dives onto non-struct elements, or the combined `validate:"..."` tag named an element
+// rule with an explicit `dive` marker.
+func (fp *fieldPlan) shouldDive() bool {
+	return fp.elemPlan != nil || len(fp.elemRules) > 0 || len(fp.legacyElemRules) > 0
+}
+
+// dive recurses into the elements of a slice, array or map field: struct
+// (or pointer-to-struct) elements are always validated recursively with an
+// indexed/keyed path such as "field[0].subfield" or "field[key].subfield".
+// Scalar elements get the field's legacy min/max/pattern tags (e.g.
+// `min:"3"` on a []string checks each string's length) plus whatever the
+// combined `validate:"..."` tag named after an explicit `dive` marker
+// (`validate:"dive,min=3"`); a combined min/max/len with no dive marker
+// describes the container's own length instead, per chunk0-1.
+func (v *StructValidator) dive(fieldVal reflect.Value, fp *fieldPlan, name string, ctx *validationContext, errs *ValidationErrors) {
+	if !fp.shouldDive() {
+		return
+	}
+
+	switch fieldVal.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fieldVal.Len(); i++ {
+			v.diveElement(fieldVal.Index(i), fp, fmt.Sprintf("%s[%d]", name, i), ctx, errs)
+		}
+	case reflect.Map:
+		keys := fieldVal.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			v.diveElement(fieldVal.MapIndex(key), fp, fmt.Sprintf("%s[%v]", name, key.Interface()), ctx, errs)
+		}
+	}
+}
+
+func (v *StructValidator) diveElement(elem reflect.Value, fp *fieldPlan, path string, ctx *validationContext, errs *ValidationErrors) {
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return
+		}
+		elem = elem.Elem()
+	}
+
+	for _, rule := range fp.legacyElemRules {
+		v.applyRule(rule.tag, rule.param, elem, path, errs)
+	}
+	for _, rule := range v.expandRules(fp.elemRules, map[string]bool{}) {
+		v.applyRule(rule.tag, rule.param, elem, path, errs)
+	}
+
+	if elem.Kind() == reflect.Struct && fp.elemPlan != nil {
+		v.validateStruct(elem, fp.elemPlan, path, ctx, errs)
+	}
+}
@@ -0,0 +1,166 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_DiveSliceOfStructs(t *testing.T) {
+	type Item struct {
+		Name string `required:"true"`
+	}
+
+	type TestStruct struct {
+		Items []Item
+	}
+
+	validator := NewValidator()
+
+	valid := TestStruct{Items: []Item{{Name: "a"}, {Name: "b"}}}
+	if err := validator.ValidateStruct(&valid); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	invalid := TestStruct{Items: []Item{{Name: "a"}, {Name: ""}}}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil || !strings.Contains(err.Error(), "items[1].name") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'items[1].name'", err)
+	}
+}
+
+func TestStructValidator_LegacyMinOnSliceOfStructsIsContainerLevel(t *testing.T) {
+	type Item struct {
+		Name string `required:"true"`
+	}
+
+	type TestStruct struct {
+		Items []Item `min:"2"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Items: []Item{{Name: "a"}, {Name: "b"}}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Items: []Item{{Name: "a"}}})
+	if err == nil || !strings.Contains(err.Error(), "field 'items'") || !strings.Contains(err.Error(), "minimum length is 2") {
+		t.Errorf("ValidateStruct() error = %v, want container-level 'minimum length is 2' on 'items'", err)
+	}
+}
+
+func TestStructValidator_DiveSliceOfPointers(t *testing.T) {
+	type Item struct {
+		Name string `required:"true"`
+	}
+
+	type TestStruct struct {
+		Items []*Item
+	}
+
+	validator := NewValidator()
+
+	invalid := TestStruct{Items: []*Item{{Name: "a"}, {Name: ""}, nil}}
+	err := validator.ValidateStruct(&invalid)
+	if err == nil || !strings.Contains(err.Error(), "items[1].name") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'items[1].name'", err)
+	}
+}
+
+// TestStructValidator_ContainerLengthRules covers chunk0-1's "length-wise
+// for strings/slices/maps" spec for the combined `validate:"..."` tag: with
+// no `dive` marker, min/max/len describe the container's own length.
+func TestStructValidator_ContainerLengthRules(t *testing.T) {
+	type TestStruct struct {
+		Tags []string `validate:"min=3"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Tags: []string{"a", "b", "c"}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Tags: []string{"a", "b"}})
+	if err == nil || !strings.Contains(err.Error(), "field 'tags'") || !strings.Contains(err.Error(), "minimum length is 3") {
+		t.Errorf("ValidateStruct() error = %v, want container-level 'minimum length is 3' on 'tags'", err)
+	}
+}
+
+// TestStructValidator_DiveScalarSlice covers chunk0-3's own example: a
+// legacy `min:"3"` tag on a []string validates each string's length.
+func TestStructValidator_DiveScalarSlice(t *testing.T) {
+	type TestStruct struct {
+		Tags []string `min:"3"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Tags: []string{"abc", "defg"}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Tags: []string{"abc", "de"}})
+	if err == nil || !strings.Contains(err.Error(), "tags[1]") || !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'tags[1]' and 'minimum length'", err)
+	}
+}
+
+// TestStructValidator_DiveCombinedTagElementRules covers the explicit
+// `dive` marker on a combined `validate:"..."` tag, which reaches the
+// same per-element behavior as the legacy form above.
+func TestStructValidator_DiveCombinedTagElementRules(t *testing.T) {
+	type TestStruct struct {
+		Tags []string `validate:"dive,min=3"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Tags: []string{"abc", "defg"}}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Tags: []string{"abc", "de"}})
+	if err == nil || !strings.Contains(err.Error(), "tags[1]") || !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'tags[1]' and 'minimum length'", err)
+	}
+}
+
+func TestStructValidator_DiveRequiredRejectsEmptyElement(t *testing.T) {
+	type TestStruct struct {
+		Tags []string `validate:"dive,required"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error for empty slice = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Tags: []string{"a", ""}})
+	if err == nil || !strings.Contains(err.Error(), "tags[1]") || !strings.Contains(err.Error(), "field is required") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'tags[1]' and 'field is required'", err)
+	}
+}
+
+func TestStructValidator_DiveMapOfStructs(t *testing.T) {
+	type Endpoint struct {
+		URL string `required:"true"`
+	}
+
+	type TestStruct struct {
+		Endpoints map[string]Endpoint
+	}
+
+	validator := NewValidator()
+
+	invalid := TestStruct{Endpoints: map[string]Endpoint{
+		"primary":   {URL: "https://example.com"},
+		"secondary": {URL: ""},
+	}}
+
+	err := validator.ValidateStruct(&invalid)
+	if err == nil || !strings.Contains(err.Error(), "endpoints[secondary].url") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'endpoints[secondary].url'", err)
+	}
+}
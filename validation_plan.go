@@ -0,0 +1,176 @@
+package envconfig
+
+import "reflect"
+
+// fieldPlan is the precomputed validation metadata for a single struct
+// field: which rules apply and, for fields that themselves need
+// recursion, a direct link to that field's own plan so the walk never has
+// to parse tags or look anything up by reflect.Type again.
+type fieldPlan struct {
+	index       int
+	localName   string
+	isStruct    bool
+	ptrToStruct bool
+	container   bool
+
+	nestedPlan *validationPlan // set when isStruct or ptrToStruct
+	elemPlan   *validationPlan // set when container and elements are (pointers to) structs
+
+	legacyRules      []tagRule // from the one-tag-per-rule form: required/min/max/pattern
+	conditionalRules []tagRule // from required_if/required_unless
+	validateRules    []tagRule // from the combined `validate:"..."` tag; aliases are expanded at apply time, not here - see expandRules
+
+	// elemRules holds the portion of the combined `validate:"..."` tag that
+	// appears after a `dive` marker. They are set only when container is
+	// true, and are applied to each element instead of to the container
+	// (validateRules holds whatever comes before dive, applied to the
+	// container itself). A container field with no `dive` marker has no
+	// elemRules at all, so a combined min/max/len describes the container
+	// (its length, its emptiness), never its elements - unlike legacyRules,
+	// whose min/max/pattern dive onto non-struct elements; see
+	// legacyElemRules.
+	elemRules []tagRule
+
+	// legacyElemRules is the subset of legacyRules - min/max/pattern, never
+	// required - that dive onto each element when container is true and
+	// the elements are not (pointers to) structs (elemPlan is nil); for
+	// struct elements it stays empty and those tags apply to the container.
+	// It is precomputed once here rather than refiltered on every element
+	// during the validate walk.
+	legacyElemRules []tagRule
+}
+
+// validationPlan is the cached, per-reflect.Type validation plan for a
+// struct type: one fieldPlan per exported field, built once.
+type validationPlan struct {
+	fields []fieldPlan
+}
+
+// planFor returns the validation plan for typ, building and caching it on
+// first use. Nested and element struct types are planned eagerly so the
+// recursive walk in validateStruct can follow fieldPlan.nestedPlan /
+// elemPlan directly instead of looking anything up.
+//
+// Building happens into goroutine-local plans (tracked by the in-progress
+// map passed to buildPlanTree) and is only published to the shared v.plans
+// cache once the whole tree rooted at typ is complete, so a concurrent
+// ValidateStruct call can never observe a partially-filled plan.fields.
+func (v *StructValidator) planFor(typ reflect.Type) *validationPlan {
+	if cached, ok := v.plans.Load(typ); ok {
+		return cached.(*validationPlan)
+	}
+
+	inProgress := make(map[reflect.Type]*validationPlan)
+	plan := v.buildPlanTree(typ, inProgress)
+
+	// Publish every plan built in this pass. If another goroutine already
+	// published a plan for one of these types in the meantime, LoadOrStore
+	// leaves that one in place. The plan returned for typ is whichever one
+	// the cache holds, so every caller gets the same pointer; plans from
+	// either goroutine are complete.
+	for t, p := range inProgress {
+		v.plans.LoadOrStore(t, p)
+	}
+	if actual, ok := v.plans.Load(typ); ok {
+		return actual.(*validationPlan)
+	}
+	return plan
+}
+
+// buildPlanTree returns the plan for typ, building it (and anything it
+// references) if needed. inProgress holds the plans already under
+// construction in this call tree, keyed by type: it lets a struct type
+// that references itself (directly or through a container) resolve to the
+// same pointer instead of recursing forever, without ever exposing that
+// not-yet-complete pointer outside the current planFor call.
+func (v *StructValidator) buildPlanTree(typ reflect.Type, inProgress map[reflect.Type]*validationPlan) *validationPlan {
+	if plan, ok := inProgress[typ]; ok {
+		return plan
+	}
+	if cached, ok := v.plans.Load(typ); ok {
+		return cached.(*validationPlan)
+	}
+
+	plan := &validationPlan{}
+	inProgress[typ] = plan
+	v.buildPlan(typ, plan, inProgress)
+	return plan
+}
+
+func (v *StructValidator) buildPlan(typ reflect.Type, plan *validationPlan, inProgress map[reflect.Type]*validationPlan) {
+	fields := make([]fieldPlan, 0, typ.NumField())
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		fp := fieldPlan{
+			index:     i,
+			localName: v.getFieldName(field, ""),
+		}
+
+		ft := field.Type
+		switch ft.Kind() {
+		case reflect.Struct:
+			fp.isStruct = true
+			fp.nestedPlan = v.buildPlanTree(ft, inProgress)
+		case reflect.Ptr:
+			if ft.Elem().Kind() == reflect.Struct {
+				fp.ptrToStruct = true
+				fp.nestedPlan = v.buildPlanTree(ft.Elem(), inProgress)
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			fp.container = true
+			elemType := ft.Elem()
+			if elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+			if elemType.Kind() == reflect.Struct {
+				fp.elemPlan = v.buildPlanTree(elemType, inProgress)
+			}
+		}
+
+		for _, tag := range []string{"required", "min", "max", "pattern"} {
+			raw, ok := field.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			if tag == "required" && raw != "true" {
+				continue
+			}
+			fp.legacyRules = append(fp.legacyRules, tagRule{tag: tag, param: raw})
+		}
+
+		for _, tag := range []string{"required_if", "required_unless"} {
+			if raw, ok := field.Tag.Lookup(tag); ok {
+				fp.conditionalRules = append(fp.conditionalRules, tagRule{tag: tag, param: raw})
+			}
+		}
+
+		rules := parseValidateTag(field.Tag.Get("validate"))
+		if fp.container {
+			fp.validateRules, fp.elemRules = splitDiveRules(rules)
+			// Struct (and pointer-to-struct) elements are always recursed
+			// into, never measured by length/pattern, so a legacy
+			// min/max/pattern tag on that kind of container describes the
+			// container itself instead of diving - otherwise it would be
+			// silently dropped (diveElement recurses into fp.elemPlan and
+			// never reaches per-element rule application).
+			if fp.elemPlan == nil {
+				for _, rule := range fp.legacyRules {
+					if rule.tag != "required" {
+						fp.legacyElemRules = append(fp.legacyElemRules, rule)
+					}
+				}
+			}
+		} else {
+			fp.validateRules = rules
+		}
+
+		fields = append(fields, fp)
+	}
+
+	plan.fields = fields
+}
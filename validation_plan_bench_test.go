@@ -0,0 +1,152 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// benchConfig mirrors the kind of nested, tag-heavy struct envconfig
+// validates on a config-reload path.
+type benchConfig struct {
+	Name     string            `validate:"required,min=3,max=32"`
+	Env      string            `validate:"oneof=dev staging prod"`
+	Port     int               `validate:"min=1,max=65535"`
+	Tags     []string          `validate:"min=2"`
+	Database benchDatabaseConf `validate:"required"`
+}
+
+type benchDatabaseConf struct {
+	Host string `validate:"required,hostname"`
+	User string `validate:"required"`
+}
+
+// newBenchConfig returns a fixture that passes every tag it carries. Tags
+// uses 2-character elements so min=2 holds whether it's read as a
+// container-level minimum element count (3 >= 2) or, as the uncached
+// baseline below still checks it, a per-element minimum length (2 >= 2) -
+// so these benchmarks measure the happy-path walk rather than the
+// error-reporting path.
+func newBenchConfig() *benchConfig {
+	return &benchConfig{
+		Name: "service",
+		Env:  "prod",
+		Port: 8080,
+		Tags: []string{"aa", "bb", "cc"},
+		Database: benchDatabaseConf{
+			Host: "db.internal",
+			User: "app",
+		},
+	}
+}
+
+// requireValidBenchConfig fails the benchmark up front if the fixture
+// itself doesn't validate, so a benchmark can never silently end up
+// measuring the error-reporting path instead of the happy path it claims.
+func requireValidBenchConfig(b *testing.B, validator Validator, cfg *benchConfig) {
+	b.Helper()
+	if err := validator.ValidateStruct(cfg); err != nil {
+		b.Fatalf("benchConfig fixture is not valid: %v", err)
+	}
+}
+
+func BenchmarkValidateStruct_Cached(b *testing.B) {
+	validator := NewValidator()
+	cfg := newBenchConfig()
+	requireValidBenchConfig(b, validator, cfg)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = validator.ValidateStruct(cfg)
+	}
+}
+
+func BenchmarkValidateStruct_Cached_Parallel(b *testing.B) {
+	validator := NewValidator()
+	cfg := newBenchConfig()
+	requireValidBenchConfig(b, validator, cfg)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = validator.ValidateStruct(cfg)
+		}
+	})
+}
+
+// uncachedValidator re-parses struct tags and walks NumField on every
+// ValidateStruct call, the way StructValidator did before it cached a
+// validationPlan per reflect.Type. It exists purely as a benchmark
+// baseline and intentionally duplicates a slice of the old tag-parsing
+// logic rather than reusing StructValidator's cache.
+type uncachedValidator struct {
+	*StructValidator
+}
+
+func (u *uncachedValidator) ValidateStruct(config any) error {
+	val := reflect.ValueOf(config)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+
+	var errs ValidationErrors
+	u.walk(val, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (u *uncachedValidator) walk(val reflect.Value, prefix string, errs *ValidationErrors) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldVal := val.Field(i)
+		name := u.getFieldName(field, prefix)
+
+		if fieldVal.Kind() == reflect.Struct {
+			u.walk(fieldVal, name, errs)
+		}
+		if fieldVal.Kind() == reflect.Slice {
+			for j := 0; j < fieldVal.Len(); j++ {
+				for _, rule := range parseTagUncached(field.Tag.Get("validate")) {
+					u.applyRule(rule.tag, rule.param, fieldVal.Index(j), name, errs)
+				}
+			}
+		}
+		for _, rule := range parseTagUncached(field.Tag.Get("validate")) {
+			u.applyRule(rule.tag, rule.param, fieldVal, name, errs)
+		}
+	}
+}
+
+func parseTagUncached(tag string) []tagRule {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, ",")
+	rules := make([]tagRule, 0, len(parts))
+	for _, part := range parts {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		rules = append(rules, tagRule{tag: name, param: param})
+	}
+	return rules
+}
+
+func BenchmarkValidateStruct_Uncached(b *testing.B) {
+	validator := &uncachedValidator{StructValidator: NewValidator()}
+	cfg := newBenchConfig()
+	requireValidBenchConfig(b, validator, cfg)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = validator.ValidateStruct(cfg)
+	}
+}
@@ -0,0 +1,99 @@
+package envconfig
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestStructValidator_PlanIsCachedPerType(t *testing.T) {
+	type TestStruct struct {
+		Name string `required:"true"`
+	}
+
+	validator := NewValidator()
+	typ := reflect.TypeOf(TestStruct{})
+
+	first := validator.planFor(typ)
+	second := validator.planFor(typ)
+
+	if first != second {
+		t.Error("planFor() returned a different plan on the second call for the same type")
+	}
+	if len(first.fields) != 1 {
+		t.Fatalf("planFor() built %d fields, want 1", len(first.fields))
+	}
+	if first.fields[0].localName != "name" {
+		t.Errorf("planFor() field localName = %q, want %q", first.fields[0].localName, "name")
+	}
+}
+
+func TestStructValidator_PlanHandlesSelfReferentialType(t *testing.T) {
+	type Node struct {
+		Name     string `required:"true"`
+		Children []*Node
+	}
+
+	validator := NewValidator()
+
+	tree := &Node{
+		Name: "root",
+		Children: []*Node{
+			{Name: "child"},
+			{Name: ""},
+		},
+	}
+
+	err := validator.ValidateStruct(tree)
+	if err == nil {
+		t.Fatal("ValidateStruct() expected error for empty nested child name")
+	}
+}
+
+func TestStructValidator_RepeatedValidationUsesCachedPlan(t *testing.T) {
+	type TestStruct struct {
+		Name string `required:"true" min:"2"`
+	}
+
+	validator := NewValidator()
+
+	for i := 0; i < 3; i++ {
+		if err := validator.ValidateStruct(&TestStruct{Name: "ok"}); err != nil {
+			t.Errorf("iteration %d: unexpected error = %v", i, err)
+		}
+		if err := validator.ValidateStruct(&TestStruct{Name: ""}); err == nil {
+			t.Errorf("iteration %d: expected error for empty name", i)
+		}
+	}
+}
+
+// TestStructValidator_ConcurrentFirstValidationIsRaceFree exercises the one
+// path PlanIsCachedPerType and RepeatedValidationUsesCachedPlan above
+// don't: many goroutines calling ValidateStruct for a type that has never
+// been planned yet, all racing to build and publish the same plan. Run
+// with -race, this must neither trip the detector nor let a goroutine
+// observe a partially-built plan (which would silently skip rules).
+func TestStructValidator_ConcurrentFirstValidationIsRaceFree(t *testing.T) {
+	type TestStruct struct {
+		Name string `required:"true"`
+	}
+
+	validator := NewValidator()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = validator.ValidateStruct(&TestStruct{})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("goroutine %d: ValidateStruct() = nil, want an error for the missing required field", i)
+		}
+	}
+}
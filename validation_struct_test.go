@@ -0,0 +1,114 @@
+package envconfig
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStructValidator_RegisterAlias(t *testing.T) {
+	validator := NewValidator()
+	validator.RegisterAlias("strong_password", "required,min=8")
+
+	type TestStruct struct {
+		Password string `validate:"strong_password"`
+	}
+
+	if err := validator.ValidateStruct(&TestStruct{Password: "longenough"}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Password: "short"})
+	if err == nil || !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'minimum length'", err)
+	}
+
+	err = validator.ValidateStruct(&TestStruct{Password: ""})
+	if err == nil || !strings.Contains(err.Error(), "field is required") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'field is required'", err)
+	}
+}
+
+// TestStructValidator_RegisterAliasAfterFirstValidation guards against
+// aliases only being resolved once, at plan-build time: a validated type's
+// cached plan must still pick up an alias registered after that first
+// call, the same way a RegisterValidation call does.
+func TestStructValidator_RegisterAliasAfterFirstValidation(t *testing.T) {
+	type TestStruct struct {
+		Password string `validate:"strong_password"`
+	}
+
+	validator := NewValidator()
+
+	if err := validator.ValidateStruct(&TestStruct{Password: "x"}); err != nil {
+		t.Fatalf("ValidateStruct() unexpected error before alias is registered = %v", err)
+	}
+
+	validator.RegisterAlias("strong_password", "required,min=8")
+
+	if err := validator.ValidateStruct(&TestStruct{Password: "longenough"}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&TestStruct{Password: "short"})
+	if err == nil || !strings.Contains(err.Error(), "minimum length") {
+		t.Errorf("ValidateStruct() error = %v, want to contain 'minimum length' now that the alias is registered", err)
+	}
+}
+
+func TestStructValidator_RegisterStructValidation(t *testing.T) {
+	type Contact struct {
+		Email string
+		Phone string
+	}
+
+	exactlyOneContact := func(s any) []ValidationError {
+		c := s.(Contact)
+		if (c.Email == "") == (c.Phone == "") {
+			return []ValidationError{{Field: "contact", Message: "exactly one of email or phone must be set"}}
+		}
+		return nil
+	}
+
+	validator := NewValidator()
+	validator.RegisterStructValidation(exactlyOneContact, Contact{})
+
+	if err := validator.ValidateStruct(&Contact{Email: "user@example.com"}); err != nil {
+		t.Errorf("ValidateStruct() unexpected error = %v", err)
+	}
+
+	err := validator.ValidateStruct(&Contact{})
+	if err == nil || !strings.Contains(err.Error(), "exactly one of email or phone") {
+		t.Errorf("ValidateStruct() error = %v, want struct-level violation", err)
+	}
+
+	err = validator.ValidateStruct(&Contact{Email: "user@example.com", Phone: "555"})
+	if err == nil || !strings.Contains(err.Error(), "exactly one of email or phone") {
+		t.Errorf("ValidateStruct() error = %v, want struct-level violation", err)
+	}
+}
+
+func TestStructValidator_RegisterStructValidation_Nested(t *testing.T) {
+	type Window struct {
+		Start int
+		End   int
+	}
+
+	type TestStruct struct {
+		Window Window
+	}
+
+	validator := NewValidator()
+	validator.RegisterStructValidation(func(s any) []ValidationError {
+		w := s.(Window)
+		if w.End <= w.Start {
+			return []ValidationError{{Field: "window", Message: fmt.Sprintf("end (%d) must be after start (%d)", w.End, w.Start)}}
+		}
+		return nil
+	}, Window{})
+
+	err := validator.ValidateStruct(&TestStruct{Window: Window{Start: 5, End: 1}})
+	if err == nil || !strings.Contains(err.Error(), "must be after start") {
+		t.Errorf("ValidateStruct() error = %v, want nested struct-level violation", err)
+	}
+}